@@ -0,0 +1,166 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/arduino/arduino-cli/legacy/builder/types"
+	"github.com/arduino/arduino-cli/legacy/builder/utils"
+	rpc "github.com/arduino/arduino-cli/rpc/commands"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/sirupsen/logrus"
+)
+
+// artifactExtensions lists the build outputs worth reporting in the
+// manifest, mirroring what the Arduino IDE surfaces after a build.
+var artifactExtensions = []string{".hex", ".bin", ".elf", ".eep", ".map"}
+
+// buildArtifactManifest enumerates the "base.<ext>" build outputs in
+// buildDir, records each one's absolute path, size and SHA-256, and -- when
+// the platform defines one -- runs the toolchain's size recipe to report
+// program/data memory usage against the board's upload limits, plus a
+// section table parsed out of the linker .map file.
+func buildArtifactManifest(builderCtx *types.Context, buildDir *paths.Path, base string) (*rpc.BuildArtifacts, error) {
+	manifest := &rpc.BuildArtifacts{}
+
+	for _, ext := range artifactExtensions {
+		artifactPath := buildDir.Join(base + ext)
+		if !artifactPath.Exist() {
+			continue
+		}
+		artifact, err := describeArtifact(artifactPath)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, artifact)
+
+		if ext == ".map" {
+			manifest.Sections = parseMapSections(artifactPath)
+		}
+	}
+
+	if elf := buildDir.Join(base + ".elf"); elf.Exist() {
+		report, err := computeSizeReport(builderCtx)
+		if err != nil {
+			logrus.WithError(err).Debug("computing size report")
+		} else {
+			manifest.SizeReport = report
+		}
+	}
+
+	return manifest, nil
+}
+
+// describeArtifact stats and checksums a single build output.
+func describeArtifact(path *paths.Path) (*rpc.Artifact, error) {
+	info, err := path.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %s", path, err)
+	}
+	data, err := path.ReadFile()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %s", path, err)
+	}
+	sum := sha256.Sum256(data)
+	return &rpc.Artifact{
+		Path:   path.String(),
+		Size:   info.Size(),
+		Sha256: hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// computeSizeReport runs the platform's recipe.size.pattern (the same
+// recipe the Arduino IDE uses to print "Sketch uses X bytes ...") and
+// parses its output with recipe.size.regex / recipe.size.regex.data against
+// upload.maximum_size / upload.maximum_data_size.
+func computeSizeReport(builderCtx *types.Context) (*rpc.SizeReport, error) {
+	props := builderCtx.BuildProperties
+	if props.Get("recipe.size.pattern") == "" {
+		return nil, fmt.Errorf("platform does not define a recipe.size.pattern")
+	}
+
+	cmd, err := utils.PrepareCommandForRecipe(props, "recipe.size.pattern", false)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running size recipe: %s", err)
+	}
+
+	report := &rpc.SizeReport{
+		MaximumProgramSize: parseInt64(props.Get("upload.maximum_size")),
+		MaximumDataSize:    parseInt64(props.Get("upload.maximum_data_size")),
+	}
+	if regex := props.Get("recipe.size.regex"); regex != "" {
+		report.ProgramSize = sumRegexMatches(regex, out.String())
+	}
+	if regex := props.Get("recipe.size.regex.data"); regex != "" {
+		report.DataSize = sumRegexMatches(regex, out.String())
+	}
+	return report, nil
+}
+
+func sumRegexMatches(pattern, text string) int64 {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, match := range re.FindAllStringSubmatch(text, -1) {
+		if len(match) < 2 {
+			continue
+		}
+		total += parseInt64(match[1])
+	}
+	return total
+}
+
+func parseInt64(s string) int64 {
+	n, _ := strconv.ParseInt(s, 10, 64)
+	return n
+}
+
+// mapSectionRegexp matches a GNU ld memory-map section line, e.g.
+// ".text          0x00000000000000a0     0x1234 ...".
+var mapSectionRegexp = regexp.MustCompile(`(?m)^(\.\S+)\s+0x([0-9a-fA-F]+)\s+0x([0-9a-fA-F]+)\b`)
+
+// parseMapSections extracts a section-name/size table from a linker .map
+// file. Parse failures are non-fatal: an empty manifest is preferable to
+// failing an otherwise-successful build.
+func parseMapSections(mapFile *paths.Path) []*rpc.MapSection {
+	data, err := mapFile.ReadFile()
+	if err != nil {
+		return nil
+	}
+	var sections []*rpc.MapSection
+	for _, match := range mapSectionRegexp.FindAllStringSubmatch(string(data), -1) {
+		size, err := strconv.ParseInt(match[3], 16, 64)
+		if err != nil {
+			continue
+		}
+		sections = append(sections, &rpc.MapSection{Name: match[1], Size: size})
+	}
+	return sections
+}
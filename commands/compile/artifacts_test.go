@@ -0,0 +1,74 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSumRegexMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		text    string
+		want    int64
+	}{
+		{"single match", `Program:\s+(\d+) bytes`, "Program:   1234 bytes", 1234},
+		{"multiple matches summed", `(\d+) bytes`, "text: 100 bytes\ndata: 20 bytes", 120},
+		{"no match", `Program:\s+(\d+) bytes`, "nothing to see here", 0},
+		{"invalid pattern", `(`, "Program: 1234 bytes", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, sumRegexMatches(tt.pattern, tt.text))
+		})
+	}
+}
+
+func TestParseMapSections(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-map-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	mapFile := paths.New(dir).Join("sketch.ino.map")
+	content := "" +
+		"Linker script and memory map\n" +
+		"\n" +
+		".text           0x0000000000000000     0x1234 build/sketch.elf\n" +
+		".data           0x0000000000001234       0x10 build/sketch.elf\n" +
+		".bss            0x0000000000001244       0x20 build/sketch.elf\n" +
+		"not a section line\n"
+	require.NoError(t, mapFile.WriteFile([]byte(content)))
+
+	sections := parseMapSections(mapFile)
+	require.Len(t, sections, 3)
+	require.Equal(t, ".text", sections[0].Name)
+	require.Equal(t, int64(0x1234), sections[0].Size)
+	require.Equal(t, ".data", sections[1].Name)
+	require.Equal(t, int64(0x10), sections[1].Size)
+	require.Equal(t, ".bss", sections[2].Name)
+	require.Equal(t, int64(0x20), sections[2].Size)
+}
+
+func TestParseMapSectionsMissingFile(t *testing.T) {
+	require.Nil(t, parseMapSections(paths.New("/nonexistent/sketch.ino.map")))
+}
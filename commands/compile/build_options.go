@@ -0,0 +1,126 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/arduino/arduino-cli/arduino/cores"
+	"github.com/arduino/arduino-cli/legacy/builder/types"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// buildOptionsFileName is the name arduino-builder itself uses to persist
+// the options a build path was generated with, so incremental builds from
+// either tool stay compatible.
+const buildOptionsFileName = "build.options.json"
+
+// buildOptions mirrors arduino-builder's build.options.json: the set of
+// parameters that materially affect the compiled output. If any of these
+// change between two invocations sharing the same build path, the existing
+// object files can no longer be trusted and the build path is wiped before
+// building again.
+type buildOptions struct {
+	HardwareDirs           []string `json:"hardwareFolders"`
+	BuiltInToolsDirs       []string `json:"builtInToolsFolders"`
+	OtherLibrariesDirs     []string `json:"otherLibrariesFolders"`
+	BuiltInLibrariesDirs   []string `json:"builtInLibrariesFolders"`
+	CustomBuildProperties  []string `json:"customBuildProperties"`
+	Fqbn                   string   `json:"fqbn"`
+	SketchLocationChecksum string   `json:"sketchLocationChecksum"`
+}
+
+// newBuildOptions captures the subset of builderCtx/fqbn that determines
+// whether a previous build can be safely reused.
+func newBuildOptions(builderCtx *types.Context, fqbn *cores.FQBN) buildOptions {
+	customProps := append([]string{}, builderCtx.CustomBuildProperties...)
+	sort.Strings(customProps)
+	return buildOptions{
+		HardwareDirs:           pathListToSortedStrings(builderCtx.HardwareDirs),
+		BuiltInToolsDirs:       pathListToSortedStrings(builderCtx.BuiltInToolsDirs),
+		OtherLibrariesDirs:     pathListToSortedStrings(builderCtx.OtherLibrariesDirs),
+		BuiltInLibrariesDirs:   pathListToSortedStrings(builderCtx.BuiltInLibrariesDirs),
+		CustomBuildProperties:  customProps,
+		Fqbn:                   fqbn.String(),
+		SketchLocationChecksum: sketchLocationChecksum(builderCtx.SketchLocation),
+	}
+}
+
+// sketchLocationChecksum returns the hex SHA-256 of the sketch path,
+// so moving a build path between sketches is detected without storing the
+// (potentially sensitive) full path in build.options.json.
+func sketchLocationChecksum(sketchLocation *paths.Path) string {
+	sum := sha256.Sum256([]byte(sketchLocation.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func pathListToSortedStrings(list paths.PathList) []string {
+	out := make([]string, len(list))
+	for i, p := range list {
+		out[i] = p.String()
+	}
+	sort.Strings(out)
+	return out
+}
+
+// equals reports whether o and other would produce the same build, i.e.
+// whether a build path built with other can be reused for o.
+func (o buildOptions) equals(other buildOptions) bool {
+	a, _ := json.Marshal(o)
+	b, _ := json.Marshal(other)
+	return bytes.Equal(a, b)
+}
+
+// loadBuildOptions reads a previously saved build.options.json. It returns a
+// nil result (not an error) if the file doesn't exist or can't be parsed,
+// since either case simply means "no information to compare against" and
+// should fall through to a full rebuild.
+func loadBuildOptions(buildOptionsPath *paths.Path) *buildOptions {
+	if !buildOptionsPath.Exist() {
+		return nil
+	}
+	data, err := buildOptionsPath.ReadFile()
+	if err != nil {
+		return nil
+	}
+	var o buildOptions
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil
+	}
+	return &o
+}
+
+// save persists o to buildOptionsPath so the next Compile invocation against
+// the same build path can tell whether it's still valid.
+func (o buildOptions) save(buildOptionsPath *paths.Path) error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return err
+	}
+	return buildOptionsPath.WriteFile(data)
+}
+
+// wipeBuildPath removes and recreates buildPath, forcing a full rebuild.
+func wipeBuildPath(buildPath *paths.Path) error {
+	if err := buildPath.RemoveAll(); err != nil {
+		return err
+	}
+	return buildPath.MkdirAll()
+}
@@ -0,0 +1,101 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildOptionsEquals(t *testing.T) {
+	base := buildOptions{
+		HardwareDirs:           []string{"/hardware"},
+		BuiltInToolsDirs:       []string{"/tools"},
+		OtherLibrariesDirs:     []string{"/libraries"},
+		BuiltInLibrariesDirs:   []string{"/ide/libraries"},
+		CustomBuildProperties:  []string{"build.extra_flags=-DFOO"},
+		Fqbn:                   "arduino:avr:uno",
+		SketchLocationChecksum: "deadbeef",
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(buildOptions) buildOptions
+		want   bool
+	}{
+		{"identical", func(o buildOptions) buildOptions { return o }, true},
+		{"different fqbn", func(o buildOptions) buildOptions {
+			o.Fqbn = "arduino:avr:mega"
+			return o
+		}, false},
+		{"different custom build properties", func(o buildOptions) buildOptions {
+			o.CustomBuildProperties = []string{"build.extra_flags=-DBAR"}
+			return o
+		}, false},
+		{"different built-in libraries dirs", func(o buildOptions) buildOptions {
+			o.BuiltInLibrariesDirs = []string{"/other/ide/libraries"}
+			return o
+		}, false},
+		{"different sketch checksum", func(o buildOptions) buildOptions {
+			o.SketchLocationChecksum = "cafef00d"
+			return o
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := tt.mutate(base)
+			require.Equal(t, tt.want, base.equals(other))
+		})
+	}
+}
+
+func TestBuildOptionsSaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-build-options-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	optionsPath := paths.New(dir).Join(buildOptionsFileName)
+
+	require.Nil(t, loadBuildOptions(optionsPath))
+
+	original := buildOptions{
+		HardwareDirs: []string{"/hardware"},
+		Fqbn:         "arduino:avr:uno",
+	}
+	require.NoError(t, original.save(optionsPath))
+
+	loaded := loadBuildOptions(optionsPath)
+	require.NotNil(t, loaded)
+	require.True(t, original.equals(*loaded))
+}
+
+func TestLoadBuildOptionsMissingOrCorrupt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-build-options-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	missing := paths.New(dir).Join(buildOptionsFileName)
+	require.Nil(t, loadBuildOptions(missing))
+
+	corrupt := paths.New(dir).Join("corrupt.json")
+	require.NoError(t, corrupt.WriteFile([]byte("not json")))
+	require.Nil(t, loadBuildOptions(corrupt))
+}
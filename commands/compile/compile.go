@@ -152,7 +152,17 @@ func Compile(ctx context.Context, req *rpc.CompileReq, outStream, errStream io.W
 		builderCtx.DebugLevel = 5
 	}
 
-	builderCtx.CustomBuildProperties = append(req.GetBuildProperties(), "build.warn_data_percentage=75")
+	// Sketch-scoped build properties (a "platform.local.txt" living next to
+	// the sketch; the sketch metadata is not read for them) override
+	// the platform's platform.txt but are themselves overridable via
+	// --build-property, so they're layered in between the two.
+	sketchBuildProperties, err := loadSketchBuildProperties(sketch)
+	if err != nil {
+		return nil, fmt.Errorf("reading sketch build properties: %s", err)
+	}
+
+	builderCtx.CustomBuildProperties = append(sketchBuildProperties, req.GetBuildProperties()...)
+	builderCtx.CustomBuildProperties = append(builderCtx.CustomBuildProperties, "build.warn_data_percentage=75")
 
 	if req.GetBuildCachePath() != "" {
 		builderCtx.BuildCachePath = paths.New(req.GetBuildCachePath())
@@ -184,6 +194,33 @@ func Compile(ctx context.Context, req *rpc.CompileReq, outStream, errStream io.W
 		builderCtx.BuiltInLibrariesDirs = paths.NewPathList(ideLibrariesPath)
 	}
 
+	// Invalidate the build path if anything that would affect its object
+	// files changed since the last build that used it, following the
+	// arduino-builder build.options.json convention. This must run after
+	// every builderCtx field that feeds newBuildOptions (custom build
+	// properties, built-in libraries dirs, ...) has been set above, or the
+	// comparison below would always see zero values and never catch a
+	// changed --libraries or --build-property.
+	var buildOptionsPath *paths.Path
+	var currentBuildOptions buildOptions
+	if builderCtx.BuildPath != nil {
+		buildOptionsPath = builderCtx.BuildPath.Join(buildOptionsFileName)
+		currentBuildOptions = newBuildOptions(builderCtx, fqbn)
+
+		rebuild := req.GetRebuildAll()
+		if !rebuild {
+			if previousBuildOptions := loadBuildOptions(buildOptionsPath); previousBuildOptions == nil || !currentBuildOptions.equals(*previousBuildOptions) {
+				rebuild = true
+			}
+		}
+		if rebuild {
+			logrus.Trace("Build options changed (or rebuild was forced): wiping build path")
+			if err := wipeBuildPath(builderCtx.BuildPath); err != nil {
+				return nil, fmt.Errorf("cleaning build path: %s", err)
+			}
+		}
+	}
+
 	builderCtx.ExecStdout = outStream
 	builderCtx.ExecStderr = errStream
 	builderCtx.SetLogger(i18n.LoggerToCustomStreams{Stdout: outStream, Stderr: errStream})
@@ -200,6 +237,13 @@ func Compile(ctx context.Context, req *rpc.CompileReq, outStream, errStream io.W
 		return nil, err
 	}
 
+	if buildOptionsPath != nil {
+		if err := currentBuildOptions.save(buildOptionsPath); err != nil {
+			logrus.WithError(err).Warn("saving build.options.json")
+		}
+	}
+
+	var artifacts *rpc.BuildArtifacts
 	if !req.GetDryRun() {
 		// FIXME: Make a function to obtain these info...
 		outputPath := paths.New(
@@ -256,8 +300,47 @@ func Compile(ctx context.Context, req *rpc.CompileReq, outStream, errStream io.W
 				return nil, fmt.Errorf("copying elf file: %s", err)
 			}
 		}
+
+		artifacts, err = buildArtifactManifest(builderCtx, outputPath.Parent(), base)
+		if err != nil {
+			return nil, fmt.Errorf("building artifact manifest: %s", err)
+		}
 	}
 
 	logrus.Tracef("Compile %s for %s successful", sketch.Name, boardArg)
-	return &rpc.CompileResp{}, nil
+	return &rpc.CompileResp{Artifacts: artifacts}, nil
+}
+
+// loadSketchBuildProperties looks for a "platform.local.txt" file next to
+// the sketch's main file and returns its content as a list of "key=value"
+// strings, in the same format as req.BuildProperties. Returns a nil slice
+// if the file isn't present.
+func loadSketchBuildProperties(sketch *sketches.Sketch) ([]string, error) {
+	sketchDir := sketch.FullPath
+	if !sketchDir.IsDir() {
+		sketchDir = sketchDir.Parent()
+	}
+
+	localProps := sketchDir.Join("platform.local.txt")
+	if !localProps.Exist() {
+		return nil, nil
+	}
+
+	props, err := properties.LoadFromPath(localProps)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %s", localProps, err)
+	}
+
+	propsMap := props.AsMap()
+	keys := make([]string, 0, len(propsMap))
+	for k := range propsMap {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, k+"="+propsMap[k])
+	}
+	return list, nil
 }
@@ -0,0 +1,128 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"sync"
+
+	rpc "github.com/arduino/arduino-cli/rpc/commands"
+	paths "github.com/arduino/go-paths-helper"
+)
+
+// CompileMatrix builds the same sketch against every FQBN in req.GetFqbns()
+// (falling back to the single req.GetFqbn()/req.GetBoard() target when that
+// list is empty), running either sequentially or in parallel bounded by
+// req.GetJobs(). Every target shares the same PackageManager (via
+// req.GetInstance()) and its already-loaded core/platform index, each
+// writing to its own build path derived from a hash of its FQBN. The sketch
+// itself is not shared: each target still calls the full Compile(), which
+// re-parses it independently.
+func CompileMatrix(ctx context.Context, req *rpc.CompileReq, outStream, errStream io.Writer, debug bool) (map[string]*rpc.CompileTargetResult, error) {
+	fqbns := req.GetFqbns()
+	if len(fqbns) == 0 {
+		if single := req.GetFqbn(); single != "" {
+			fqbns = []string{single}
+		} else {
+			fqbns = []string{req.GetBoard()}
+		}
+	}
+
+	jobs := int(req.GetJobs())
+	if jobs <= 0 {
+		jobs = 1
+	}
+
+	// Targets run concurrently but share outStream/errStream: wrap them so
+	// concurrent writers can't race on (or interleave mid-write into) the
+	// underlying io.Writer.
+	sharedOut := &syncWriter{w: outStream}
+	sharedErr := &syncWriter{w: errStream}
+
+	results := make(map[string]*rpc.CompileTargetResult, len(fqbns))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, jobs)
+
+	for _, fqbn := range fqbns {
+		fqbn := fqbn
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			targetReq := *req
+			targetReq.Fqbn = fqbn
+			targetReq.Board = fqbn
+			targetReq.Fqbns = nil
+			targetReq.BuildPath = matrixTargetBuildPath(req, fqbn).String()
+
+			resp, err := Compile(ctx, &targetReq, sharedOut, sharedErr, debug)
+			result := &rpc.CompileTargetResult{
+				Fqbn:      fqbn,
+				Success:   err == nil,
+				BuildPath: targetReq.BuildPath,
+			}
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Artifacts = resp.GetArtifacts()
+			}
+
+			resultsMu.Lock()
+			results[fqbn] = result
+			resultsMu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// syncWriter serializes concurrent writers onto a single io.Writer, so
+// matrix targets building in parallel can share outStream/errStream without
+// racing on it or tearing each other's writes.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// matrixTargetBuildPath derives a per-FQBN build path so concurrent matrix
+// targets never collide, nesting under the user-provided build path when
+// one was given.
+func matrixTargetBuildPath(req *rpc.CompileReq, fqbn string) *paths.Path {
+	dirName := fqbnBuildDirName(fqbn)
+	if base := req.GetBuildPath(); base != "" {
+		return paths.New(base).Join(dirName)
+	}
+	return paths.TempDir().Join("arduino-compile-matrix", dirName)
+}
+
+// fqbnBuildDirName returns a filesystem-safe, stable directory name for fqbn.
+func fqbnBuildDirName(fqbn string) string {
+	sum := sha256.Sum256([]byte(fqbn))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,42 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFqbnBuildDirName(t *testing.T) {
+	a := fqbnBuildDirName("arduino:avr:uno")
+	b := fqbnBuildDirName("arduino:avr:mega")
+	require.NotEmpty(t, a)
+	require.NotEqual(t, a, b)
+	require.Equal(t, a, fqbnBuildDirName("arduino:avr:uno"))
+}
+
+func TestMatrixTargetBuildPath(t *testing.T) {
+	withoutBuildPath := &rpc.CompileReq{}
+	p := matrixTargetBuildPath(withoutBuildPath, "arduino:avr:uno")
+	require.Contains(t, p.String(), "arduino-compile-matrix")
+	require.Contains(t, p.String(), fqbnBuildDirName("arduino:avr:uno"))
+
+	withBuildPath := &rpc.CompileReq{BuildPath: "/tmp/my-build"}
+	p = matrixTargetBuildPath(withBuildPath, "arduino:avr:uno")
+	require.Equal(t, "/tmp/my-build/"+fqbnBuildDirName("arduino:avr:uno"), p.String())
+}
@@ -0,0 +1,68 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/arduino/arduino-cli/arduino/sketches"
+	paths "github.com/arduino/go-paths-helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSketchBuildPropertiesNoOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-sketch-build-properties-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sketch := &sketches.Sketch{FullPath: paths.New(dir)}
+	props, err := loadSketchBuildProperties(sketch)
+	require.NoError(t, err)
+	require.Nil(t, props)
+}
+
+func TestLoadSketchBuildPropertiesPlatformLocalTxt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-sketch-build-properties-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	localProps := paths.New(dir).Join("platform.local.txt")
+	require.NoError(t, localProps.WriteFile([]byte("compiler.c.extra_flags=-DFOO\nbuild.extra_flags=-DBAR\n")))
+
+	sketch := &sketches.Sketch{FullPath: paths.New(dir)}
+	props, err := loadSketchBuildProperties(sketch)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"build.extra_flags=-DBAR",
+		"compiler.c.extra_flags=-DFOO",
+	}, props)
+}
+
+func TestLoadSketchBuildPropertiesUnreadablePlatformLocalTxt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "arduino-cli-sketch-build-properties-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A directory named platform.local.txt passes the .Exist() check but
+	// fails to load as a properties file, exercising the error path.
+	require.NoError(t, paths.New(dir).Join("platform.local.txt").MkdirAll())
+
+	sketch := &sketches.Sketch{FullPath: paths.New(dir)}
+	_, err = loadSketchBuildProperties(sketch)
+	require.Error(t, err)
+}
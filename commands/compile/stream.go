@@ -0,0 +1,213 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	rpc "github.com/arduino/arduino-cli/rpc/commands"
+)
+
+// CompileStreamSender is the shape a gRPC server-streaming handler for the
+// Compile RPC would implement (matching the Send method of the
+// stream.Server generated for a "rpc Compile(CompileReq) returns (stream
+// CompileResp)" method). No such service is defined in compile.proto yet and
+// nothing in this package registers one, so CompileStreaming below is not
+// wired to any live RPC today; it's the streaming-capable building block for
+// when that service is added.
+type CompileStreamSender interface {
+	Send(*rpc.CompileResp) error
+}
+
+// CompileStreaming runs Compile but routes outStream/errStream through
+// stream as typed CompileResp messages instead of writing plain text. It has
+// no caller in this tree yet; see the CompileStreamSender doc comment.
+func CompileStreaming(ctx context.Context, req *rpc.CompileReq, stream CompileStreamSender, debug bool) error {
+	tracker := newBuildProgressTracker(stream)
+	outStream := newDiagnosticStreamWriter(stream, tracker, false)
+	errStream := newDiagnosticStreamWriter(stream, tracker, true)
+	_, err := Compile(ctx, req, outStream, errStream, debug)
+	return err
+}
+
+// buildPhases lists, in order, the build phases we can recognize in
+// arduino-builder's own log output. Each entry is matched by the log line
+// that marks its start, except "objcopy", which is matched by the
+// "Using library" lines instead. Progress is reported as the (1-based)
+// position of the last phase seen so far, as a percentage of
+// len(buildPhases).
+var buildPhases = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"preprocess", regexp.MustCompile(`(?i)^Detecting libraries used`)},
+	{"core", regexp.MustCompile(`(?i)^Compiling core`)},
+	{"sketch", regexp.MustCompile(`(?i)^Compiling sketch`)},
+	{"libraries", regexp.MustCompile(`(?i)^Compiling libraries`)},
+	{"link", regexp.MustCompile(`(?i)^Linking everything together`)},
+	{"objcopy", regexp.MustCompile(`(?i)^Using library`)},
+}
+
+// buildProgressTracker derives a normalized 0-100 progress percentage from
+// the build phase transitions recognized in the builder's log output.
+type buildProgressTracker struct {
+	stream    CompileStreamSender
+	lastPhase int
+}
+
+func newBuildProgressTracker(stream CompileStreamSender) *buildProgressTracker {
+	return &buildProgressTracker{stream: stream, lastPhase: -1}
+}
+
+// observe inspects a single line of builder output and, if it marks the
+// start of a new phase, sends a progress update.
+func (t *buildProgressTracker) observe(line []byte) {
+	for i, phase := range buildPhases {
+		if i <= t.lastPhase {
+			continue
+		}
+		if phase.pattern.Match(line) {
+			t.lastPhase = i
+			percent := int32((i + 1) * 100 / len(buildPhases))
+			_ = t.stream.Send(&rpc.CompileResp{Progress: &rpc.TaskProgress{Percent: percent}})
+			break
+		}
+	}
+}
+
+// diagnosticRegexp matches the GCC plain-text diagnostic format:
+// "file:line:col: severity: message". The file component is matched greedily
+// so it can itself contain colons (e.g. a Windows path like
+// "C:\Users\...\sketch.ino"); backtracking still anchors line/col/severity
+// on the last three colon-separated fields.
+var diagnosticRegexp = regexp.MustCompile(`^(.+):(\d+):(\d+): (error|warning|note): (.+)$`)
+
+// gccJSONDiagnostic mirrors the shape GCC emits per-diagnostic when invoked
+// with -fdiagnostics-format=json. Nothing in this package adds that flag to
+// a compile recipe, so today this branch only fires for platforms whose own
+// platform.txt already passes it; every other toolchain falls through to
+// diagnosticRegexp below.
+type gccJSONDiagnostic struct {
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+	Children []struct {
+		Message string `json:"message"`
+	} `json:"children"`
+	Locations []struct {
+		Caret struct {
+			File   string `json:"file"`
+			Line   int32  `json:"line"`
+			Column int32  `json:"column"`
+		} `json:"caret"`
+	} `json:"locations"`
+}
+
+// diagnosticStreamWriter is an io.Writer adapter that multiplexes raw output
+// chunks, progress updates and structured diagnostics onto a
+// CompileStreamSender. Lines are buffered until a newline is seen so
+// diagnostics (which may come either as one GCC JSON array per line or as
+// one plain-text line per diagnostic) are never parsed out of a partial
+// write.
+type diagnosticStreamWriter struct {
+	stream   CompileStreamSender
+	tracker  *buildProgressTracker
+	isStderr bool
+	buf      bytes.Buffer
+}
+
+func newDiagnosticStreamWriter(stream CompileStreamSender, tracker *buildProgressTracker, isStderr bool) *diagnosticStreamWriter {
+	return &diagnosticStreamWriter{stream: stream, tracker: tracker, isStderr: isStderr}
+}
+
+func (w *diagnosticStreamWriter) Write(p []byte) (int, error) {
+	if w.isStderr {
+		_ = w.stream.Send(&rpc.CompileResp{ErrStream: p})
+	} else {
+		_ = w.stream.Send(&rpc.CompileResp{OutStream: p})
+	}
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadBytes('\n')
+		if err != nil {
+			// Incomplete line: put it back for the next Write and stop.
+			w.buf.Reset()
+			w.buf.Write(line)
+			break
+		}
+		w.tracker.observe(line)
+		if diag, ok := parseDiagnostic(line); ok {
+			_ = w.stream.Send(&rpc.CompileResp{Diagnostic: diag})
+		}
+	}
+	return len(p), nil
+}
+
+// parseDiagnostic extracts a structured Diagnostic out of a single line of
+// toolchain output. It tries GCC's -fdiagnostics-format=json array format
+// first, but in practice only platforms that enable that flag themselves
+// will ever hit it; everything else is parsed via the plain-text
+// "file:line:col: severity: message" format in diagnosticRegexp.
+func parseDiagnostic(line []byte) (*rpc.Diagnostic, bool) {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return nil, false
+	}
+
+	if trimmed[0] == '[' {
+		var diags []gccJSONDiagnostic
+		if err := json.Unmarshal(trimmed, &diags); err == nil && len(diags) > 0 {
+			d := diags[0]
+			var file string
+			var lineNo, col int32
+			if len(d.Locations) > 0 {
+				file = d.Locations[0].Caret.File
+				lineNo = d.Locations[0].Caret.Line
+				col = d.Locations[0].Caret.Column
+			}
+			context := ""
+			if len(d.Children) > 0 {
+				context = d.Children[0].Message
+			}
+			return &rpc.Diagnostic{
+				Severity: d.Kind,
+				File:     file,
+				Line:     lineNo,
+				Column:   col,
+				Message:  d.Message,
+				Context:  context,
+			}, true
+		}
+	}
+
+	if m := diagnosticRegexp.FindSubmatch(trimmed); m != nil {
+		lineNo, _ := strconv.ParseInt(string(m[2]), 10, 32)
+		col, _ := strconv.ParseInt(string(m[3]), 10, 32)
+		return &rpc.Diagnostic{
+			Severity: string(m[4]),
+			File:     string(m[1]),
+			Line:     int32(lineNo),
+			Column:   int32(col),
+			Message:  string(m[5]),
+		}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,99 @@
+// This file is part of arduino-cli.
+//
+// Copyright 2020 ARDUINO SA (http://www.arduino.cc/)
+//
+// This software is released under the GNU General Public License version 3,
+// which covers the main part of arduino-cli.
+// The terms of this license can be found at:
+// https://www.gnu.org/licenses/gpl-3.0.en.html
+//
+// You can be released from the requirements of the above licenses by purchasing
+// a commercial license. Buying such a license is mandatory if you want to
+// modify or otherwise use the software for commercial activities involving the
+// Arduino software without disclosing the source code of your own applications.
+// To purchase a commercial license, send an email to license@arduino.cc.
+
+package compile
+
+import (
+	"testing"
+
+	rpc "github.com/arduino/arduino-cli/rpc/commands"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiagnostic(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *rpc.Diagnostic
+	}{
+		{
+			name: "plain-text error",
+			line: "/tmp/sketch/sketch.ino:12:3: error: 'foo' was not declared in this scope",
+			want: &rpc.Diagnostic{
+				Severity: "error",
+				File:     "/tmp/sketch/sketch.ino",
+				Line:     12,
+				Column:   3,
+				Message:  "'foo' was not declared in this scope",
+			},
+		},
+		{
+			name: "plain-text warning",
+			line: "/tmp/sketch/sketch.ino:5:1: warning: unused variable 'x'",
+			want: &rpc.Diagnostic{
+				Severity: "warning",
+				File:     "/tmp/sketch/sketch.ino",
+				Line:     5,
+				Column:   1,
+				Message:  "unused variable 'x'",
+			},
+		},
+		{
+			name: "gcc json diagnostic",
+			line: `[{"kind":"error","message":"'foo' was not declared","children":[{"message":"did you mean 'bar'?"}],"locations":[{"caret":{"file":"sketch.ino","line":7,"column":4}}]}]`,
+			want: &rpc.Diagnostic{
+				Severity: "error",
+				File:     "sketch.ino",
+				Line:     7,
+				Column:   4,
+				Message:  "'foo' was not declared",
+				Context:  "did you mean 'bar'?",
+			},
+		},
+		{
+			name: `plain-text error with Windows drive-letter path`,
+			line: `C:\Users\ardu\AppData\Local\Temp\sketch\sketch.ino:12:3: error: 'foo' was not declared in this scope`,
+			want: &rpc.Diagnostic{
+				Severity: "error",
+				File:     `C:\Users\ardu\AppData\Local\Temp\sketch\sketch.ino`,
+				Line:     12,
+				Column:   3,
+				Message:  "'foo' was not declared in this scope",
+			},
+		},
+		{
+			name: "not a diagnostic",
+			line: "Compiling sketch...",
+			want: nil,
+		},
+		{
+			name: "blank line",
+			line: "   ",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseDiagnostic([]byte(tt.line))
+			if tt.want == nil {
+				require.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
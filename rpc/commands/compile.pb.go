@@ -0,0 +1,550 @@
+// Hand-maintained Go types mirroring commands/compile.proto.
+//
+// This is not protoc-gen-go output: there's no protoc toolchain wired into
+// this tree yet, so the message structs, getters and proto.RegisterType
+// calls below are written by hand to match what protoc-gen-go would produce.
+// Regenerate this file with protoc-gen-go once the proto build step lands,
+// and delete this comment.
+
+package commands
+
+import (
+	proto "github.com/golang/protobuf/proto"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+
+// Instance is an opaque reference to a CLI core instance created via the
+// Init RPC. Every other RPC that needs package-manager state takes one.
+type Instance struct {
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Instance) Reset()         { *m = Instance{} }
+func (m *Instance) String() string { return proto.CompactTextString(m) }
+func (*Instance) ProtoMessage()    {}
+
+func (m *Instance) GetId() int32 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+// CompileReq is the request message for the Compile RPC.
+type CompileReq struct {
+	Instance         *Instance `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Fqbn             string    `protobuf:"bytes,2,opt,name=fqbn,proto3" json:"fqbn,omitempty"`
+	SketchPath       string    `protobuf:"bytes,3,opt,name=sketchPath,proto3" json:"sketchPath,omitempty"`
+	ShowProperties   bool      `protobuf:"varint,4,opt,name=showProperties,proto3" json:"showProperties,omitempty"`
+	Preprocess       bool      `protobuf:"varint,5,opt,name=preprocess,proto3" json:"preprocess,omitempty"`
+	BuildCachePath   string    `protobuf:"bytes,6,opt,name=buildCachePath,proto3" json:"buildCachePath,omitempty"`
+	BuildPath        string    `protobuf:"bytes,7,opt,name=buildPath,proto3" json:"buildPath,omitempty"`
+	BuildProperties  []string  `protobuf:"bytes,8,rep,name=buildProperties,proto3" json:"buildProperties,omitempty"`
+	Warnings         string    `protobuf:"bytes,9,opt,name=warnings,proto3" json:"warnings,omitempty"`
+	Verbose          bool      `protobuf:"varint,10,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	Quiet            bool      `protobuf:"varint,11,opt,name=quiet,proto3" json:"quiet,omitempty"`
+	VidPid           string    `protobuf:"bytes,12,opt,name=vidPid,proto3" json:"vidPid,omitempty"`
+	ExportFile       string    `protobuf:"bytes,13,opt,name=exportFile,proto3" json:"exportFile,omitempty"`
+	Jobs             int32     `protobuf:"varint,14,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	Libraries        []string  `protobuf:"bytes,15,rep,name=libraries,proto3" json:"libraries,omitempty"`
+	OptimizeForDebug bool      `protobuf:"varint,16,opt,name=optimizeForDebug,proto3" json:"optimizeForDebug,omitempty"`
+	DryRun           bool      `protobuf:"varint,17,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Board            string    `protobuf:"bytes,18,opt,name=board,proto3" json:"board,omitempty"`
+	RebuildAll       bool      `protobuf:"varint,19,opt,name=rebuildAll,proto3" json:"rebuildAll,omitempty"`
+	Fqbns            []string  `protobuf:"bytes,20,rep,name=fqbns,proto3" json:"fqbns,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompileReq) Reset()         { *m = CompileReq{} }
+func (m *CompileReq) String() string { return proto.CompactTextString(m) }
+func (*CompileReq) ProtoMessage()    {}
+
+func (m *CompileReq) GetInstance() *Instance {
+	if m != nil {
+		return m.Instance
+	}
+	return nil
+}
+
+func (m *CompileReq) GetFqbn() string {
+	if m != nil {
+		return m.Fqbn
+	}
+	return ""
+}
+
+func (m *CompileReq) GetSketchPath() string {
+	if m != nil {
+		return m.SketchPath
+	}
+	return ""
+}
+
+func (m *CompileReq) GetShowProperties() bool {
+	if m != nil {
+		return m.ShowProperties
+	}
+	return false
+}
+
+func (m *CompileReq) GetPreprocess() bool {
+	if m != nil {
+		return m.Preprocess
+	}
+	return false
+}
+
+func (m *CompileReq) GetBuildCachePath() string {
+	if m != nil {
+		return m.BuildCachePath
+	}
+	return ""
+}
+
+func (m *CompileReq) GetBuildPath() string {
+	if m != nil {
+		return m.BuildPath
+	}
+	return ""
+}
+
+func (m *CompileReq) GetBuildProperties() []string {
+	if m != nil {
+		return m.BuildProperties
+	}
+	return nil
+}
+
+func (m *CompileReq) GetWarnings() string {
+	if m != nil {
+		return m.Warnings
+	}
+	return ""
+}
+
+func (m *CompileReq) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
+func (m *CompileReq) GetQuiet() bool {
+	if m != nil {
+		return m.Quiet
+	}
+	return false
+}
+
+func (m *CompileReq) GetVidPid() string {
+	if m != nil {
+		return m.VidPid
+	}
+	return ""
+}
+
+func (m *CompileReq) GetExportFile() string {
+	if m != nil {
+		return m.ExportFile
+	}
+	return ""
+}
+
+func (m *CompileReq) GetJobs() int32 {
+	if m != nil {
+		return m.Jobs
+	}
+	return 0
+}
+
+func (m *CompileReq) GetLibraries() []string {
+	if m != nil {
+		return m.Libraries
+	}
+	return nil
+}
+
+func (m *CompileReq) GetOptimizeForDebug() bool {
+	if m != nil {
+		return m.OptimizeForDebug
+	}
+	return false
+}
+
+func (m *CompileReq) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *CompileReq) GetBoard() string {
+	if m != nil {
+		return m.Board
+	}
+	return ""
+}
+
+func (m *CompileReq) GetRebuildAll() bool {
+	if m != nil {
+		return m.RebuildAll
+	}
+	return false
+}
+
+func (m *CompileReq) GetFqbns() []string {
+	if m != nil {
+		return m.Fqbns
+	}
+	return nil
+}
+
+// CompileResp is the response message for the Compile RPC.
+type CompileResp struct {
+	OutStream  []byte          `protobuf:"bytes,1,opt,name=outStream,proto3" json:"outStream,omitempty"`
+	ErrStream  []byte          `protobuf:"bytes,2,opt,name=errStream,proto3" json:"errStream,omitempty"`
+	Progress   *TaskProgress   `protobuf:"bytes,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	Diagnostic *Diagnostic     `protobuf:"bytes,4,opt,name=diagnostic,proto3" json:"diagnostic,omitempty"`
+	Artifacts  *BuildArtifacts `protobuf:"bytes,5,opt,name=artifacts,proto3" json:"artifacts,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompileResp) Reset()         { *m = CompileResp{} }
+func (m *CompileResp) String() string { return proto.CompactTextString(m) }
+func (*CompileResp) ProtoMessage()    {}
+
+func (m *CompileResp) GetOutStream() []byte {
+	if m != nil {
+		return m.OutStream
+	}
+	return nil
+}
+
+func (m *CompileResp) GetErrStream() []byte {
+	if m != nil {
+		return m.ErrStream
+	}
+	return nil
+}
+
+func (m *CompileResp) GetProgress() *TaskProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (m *CompileResp) GetDiagnostic() *Diagnostic {
+	if m != nil {
+		return m.Diagnostic
+	}
+	return nil
+}
+
+func (m *CompileResp) GetArtifacts() *BuildArtifacts {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+// TaskProgress is a normalized progress update for a long-running task.
+type TaskProgress struct {
+	Percent int32 `protobuf:"varint,1,opt,name=percent,proto3" json:"percent,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskProgress) Reset()         { *m = TaskProgress{} }
+func (m *TaskProgress) String() string { return proto.CompactTextString(m) }
+func (*TaskProgress) ProtoMessage()    {}
+
+func (m *TaskProgress) GetPercent() int32 {
+	if m != nil {
+		return m.Percent
+	}
+	return 0
+}
+
+// Diagnostic is a structured compiler error/warning/note.
+type Diagnostic struct {
+	Severity string `protobuf:"bytes,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	File     string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Line     int32  `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	Column   int32  `protobuf:"varint,4,opt,name=column,proto3" json:"column,omitempty"`
+	Message  string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Context  string `protobuf:"bytes,6,opt,name=context,proto3" json:"context,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Diagnostic) Reset()         { *m = Diagnostic{} }
+func (m *Diagnostic) String() string { return proto.CompactTextString(m) }
+func (*Diagnostic) ProtoMessage()    {}
+
+func (m *Diagnostic) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func (m *Diagnostic) GetFile() string {
+	if m != nil {
+		return m.File
+	}
+	return ""
+}
+
+func (m *Diagnostic) GetLine() int32 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *Diagnostic) GetColumn() int32 {
+	if m != nil {
+		return m.Column
+	}
+	return 0
+}
+
+func (m *Diagnostic) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Diagnostic) GetContext() string {
+	if m != nil {
+		return m.Context
+	}
+	return ""
+}
+
+// Artifact describes one build output file.
+type Artifact struct {
+	Path   string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size   int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Artifact) Reset()         { *m = Artifact{} }
+func (m *Artifact) String() string { return proto.CompactTextString(m) }
+func (*Artifact) ProtoMessage()    {}
+
+func (m *Artifact) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *Artifact) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Artifact) GetSha256() string {
+	if m != nil {
+		return m.Sha256
+	}
+	return ""
+}
+
+// MapSection is one entry of a linker .map file's section table.
+type MapSection struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MapSection) Reset()         { *m = MapSection{} }
+func (m *MapSection) String() string { return proto.CompactTextString(m) }
+func (*MapSection) ProtoMessage()    {}
+
+func (m *MapSection) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MapSection) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// SizeReport is the parsed result of the platform's recipe.size.pattern.
+type SizeReport struct {
+	ProgramSize        int64 `protobuf:"varint,1,opt,name=programSize,proto3" json:"programSize,omitempty"`
+	MaximumProgramSize int64 `protobuf:"varint,2,opt,name=maximumProgramSize,proto3" json:"maximumProgramSize,omitempty"`
+	DataSize           int64 `protobuf:"varint,3,opt,name=dataSize,proto3" json:"dataSize,omitempty"`
+	MaximumDataSize    int64 `protobuf:"varint,4,opt,name=maximumDataSize,proto3" json:"maximumDataSize,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SizeReport) Reset()         { *m = SizeReport{} }
+func (m *SizeReport) String() string { return proto.CompactTextString(m) }
+func (*SizeReport) ProtoMessage()    {}
+
+func (m *SizeReport) GetProgramSize() int64 {
+	if m != nil {
+		return m.ProgramSize
+	}
+	return 0
+}
+
+func (m *SizeReport) GetMaximumProgramSize() int64 {
+	if m != nil {
+		return m.MaximumProgramSize
+	}
+	return 0
+}
+
+func (m *SizeReport) GetDataSize() int64 {
+	if m != nil {
+		return m.DataSize
+	}
+	return 0
+}
+
+func (m *SizeReport) GetMaximumDataSize() int64 {
+	if m != nil {
+		return m.MaximumDataSize
+	}
+	return 0
+}
+
+// BuildArtifacts is the post-build manifest of a successful Compile.
+type BuildArtifacts struct {
+	Files      []*Artifact   `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Sections   []*MapSection `protobuf:"bytes,2,rep,name=sections,proto3" json:"sections,omitempty"`
+	SizeReport *SizeReport   `protobuf:"bytes,3,opt,name=sizeReport,proto3" json:"sizeReport,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BuildArtifacts) Reset()         { *m = BuildArtifacts{} }
+func (m *BuildArtifacts) String() string { return proto.CompactTextString(m) }
+func (*BuildArtifacts) ProtoMessage()    {}
+
+func (m *BuildArtifacts) GetFiles() []*Artifact {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *BuildArtifacts) GetSections() []*MapSection {
+	if m != nil {
+		return m.Sections
+	}
+	return nil
+}
+
+func (m *BuildArtifacts) GetSizeReport() *SizeReport {
+	if m != nil {
+		return m.SizeReport
+	}
+	return nil
+}
+
+// CompileTargetResult is the per-FQBN outcome of a CompileMatrix build.
+type CompileTargetResult struct {
+	Fqbn      string          `protobuf:"bytes,1,opt,name=fqbn,proto3" json:"fqbn,omitempty"`
+	Success   bool            `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string          `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	BuildPath string          `protobuf:"bytes,4,opt,name=buildPath,proto3" json:"buildPath,omitempty"`
+	Artifacts *BuildArtifacts `protobuf:"bytes,5,opt,name=artifacts,proto3" json:"artifacts,omitempty"`
+
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompileTargetResult) Reset()         { *m = CompileTargetResult{} }
+func (m *CompileTargetResult) String() string { return proto.CompactTextString(m) }
+func (*CompileTargetResult) ProtoMessage()    {}
+
+func (m *CompileTargetResult) GetFqbn() string {
+	if m != nil {
+		return m.Fqbn
+	}
+	return ""
+}
+
+func (m *CompileTargetResult) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *CompileTargetResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CompileTargetResult) GetBuildPath() string {
+	if m != nil {
+		return m.BuildPath
+	}
+	return ""
+}
+
+func (m *CompileTargetResult) GetArtifacts() *BuildArtifacts {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Instance)(nil), "cc.arduino.cli.commands.Instance")
+	proto.RegisterType((*CompileReq)(nil), "cc.arduino.cli.commands.CompileReq")
+	proto.RegisterType((*CompileResp)(nil), "cc.arduino.cli.commands.CompileResp")
+	proto.RegisterType((*TaskProgress)(nil), "cc.arduino.cli.commands.TaskProgress")
+	proto.RegisterType((*Diagnostic)(nil), "cc.arduino.cli.commands.Diagnostic")
+	proto.RegisterType((*Artifact)(nil), "cc.arduino.cli.commands.Artifact")
+	proto.RegisterType((*MapSection)(nil), "cc.arduino.cli.commands.MapSection")
+	proto.RegisterType((*SizeReport)(nil), "cc.arduino.cli.commands.SizeReport")
+	proto.RegisterType((*BuildArtifacts)(nil), "cc.arduino.cli.commands.BuildArtifacts")
+	proto.RegisterType((*CompileTargetResult)(nil), "cc.arduino.cli.commands.CompileTargetResult")
+}